@@ -4,17 +4,93 @@
 package client
 
 import (
-	"bytes"
-	"encoding/json"
+	"encoding/base64"
 	"fmt"
-	"strconv"
+	"io/ioutil"
+	"math/rand"
+	"os"
 	"strings"
+	"time"
 
-	cmd "github.com/aws/copilot-cli/e2e/internal/command"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/aws/aws-sdk-go/service/efs"
+	"github.com/aws/aws-sdk-go/service/rds"
 )
 
-// AWS is a wrapper around aws commands.
-type AWS struct{}
+// Environment variables that, when set, make NewAWS assume a role before talking to AWS.
+// This lets the e2e suite run against a sandbox account reached via STS AssumeRole from a
+// CI identity, instead of requiring long-lived IAM user keys.
+const (
+	envAssumeRoleARN = "E2E_ASSUME_ROLE_ARN"
+	envExternalID    = "E2E_EXTERNAL_ID"
+
+	assumeRoleSessionName = "copilot-e2e"
+)
+
+// defaultRetryMaxAttempts and defaultRetryBaseDelay give a retrying operation roughly a
+// few minutes to ride out AWS throttling. Only the error codes in retryableErrCodes are
+// retried; any other error, including an eventual-consistency error, is returned at once.
+const (
+	defaultRetryMaxAttempts = 30
+	defaultRetryBaseDelay   = 2 * time.Second
+)
+
+// retryableErrCodes are AWS error codes worth retrying with backoff instead of failing the
+// e2e run outright.
+var retryableErrCodes = map[string]bool{
+	"Throttling":               true,
+	"ThrottlingException":      true,
+	"RequestLimitExceeded":     true,
+	"TooManyRequestsException": true,
+}
+
+// maxRetryDelay caps the exponential backoff so defaultRetryMaxAttempts attempts finish in
+// a few minutes rather than growing unbounded.
+const maxRetryDelay = 30 * time.Second
+
+// retryPolicy controls how execWithRetry backs off between attempts.
+type retryPolicy struct {
+	maxAttempts int
+	baseDelay   time.Duration
+}
+
+// backoff returns the delay before retry attempt (0-indexed), with exponential growth
+// capped at maxRetryDelay and jitter added to avoid thundering-herd retries.
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	delay := p.baseDelay * time.Duration(1<<uint(attempt))
+	if delay > maxRetryDelay || delay <= 0 {
+		delay = maxRetryDelay
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2+1)))
+}
+
+// AWS is a wrapper around the AWS SDK for Go clients used by the e2e suite.
+type AWS struct {
+	sess *session.Session
+
+	cfn *cloudformation.CloudFormation
+	ecr *ecr.ECR
+	efs *efs.EFS
+	rds *rds.RDS
+
+	retry retryPolicy
+}
+
+// AWSOption configures an AWS client returned by NewAWS or NewAWSWithRole.
+type AWSOption func(*AWS)
+
+// WithRetry overrides the default retry policy used for transient AWS failures: up to
+// maxAttempts calls, with exponential backoff (plus jitter) starting at baseDelay.
+func WithRetry(maxAttempts int, baseDelay time.Duration) AWSOption {
+	return func(a *AWS) {
+		a.retry = retryPolicy{maxAttempts: maxAttempts, baseDelay: baseDelay}
+	}
+}
 
 // VPCStackOutput is the output for VPC stack.
 type VPCStackOutput struct {
@@ -23,198 +99,606 @@ type VPCStackOutput struct {
 	ExportName  string
 }
 
-// dbClusterSnapshot represents part of the response to `aws rds describe-db-cluster-snapshots`
-type dbClusterSnapshot struct {
-	Identifier string `json:"DBClusterSnapshotIdentifier"`
-	Cluster    string `json:"DBClusterIdentifier"`
+// StackDetails is the status, parameters, outputs, and tags of a CloudFormation stack.
+type StackDetails struct {
+	Status     string
+	Parameters map[string]string
+	Outputs    map[string]string
+	Tags       map[string]string
+}
+
+// StackEvent is a single entry in a CloudFormation stack's event log.
+type StackEvent struct {
+	Timestamp            time.Time
+	LogicalResourceID    string
+	ResourceType         string
+	ResourceStatus       string
+	ResourceStatusReason string
 }
 
-// NewAWS returns a wrapper around AWS commands.
-func NewAWS() *AWS {
-	return &AWS{}
+// CreateStackOption configures the CreateStack call.
+type CreateStackOption func(*cloudformation.CreateStackInput)
+
+// WithParameters sets the stack parameters passed to CreateStack.
+func WithParameters(params map[string]string) CreateStackOption {
+	return func(in *cloudformation.CreateStackInput) {
+		for k, v := range params {
+			in.Parameters = append(in.Parameters, &cloudformation.Parameter{
+				ParameterKey:   aws.String(k),
+				ParameterValue: aws.String(v),
+			})
+		}
+	}
+}
+
+// WithTags sets the tags passed to CreateStack.
+func WithTags(tags map[string]string) CreateStackOption {
+	return func(in *cloudformation.CreateStackInput) {
+		for k, v := range tags {
+			in.Tags = append(in.Tags, &cloudformation.Tag{
+				Key:   aws.String(k),
+				Value: aws.String(v),
+			})
+		}
+	}
+}
+
+// WithCapabilities sets the IAM capabilities (e.g. CAPABILITY_IAM) acknowledged by CreateStack.
+func WithCapabilities(capabilities []string) CreateStackOption {
+	return func(in *cloudformation.CreateStackInput) {
+		in.Capabilities = aws.StringSlice(capabilities)
+	}
+}
+
+// NewAWS returns a wrapper around the AWS SDK for Go clients, backed by a shared session.
+// If E2E_ASSUME_ROLE_ARN is set, the session's credentials are obtained by assuming that
+// role, optionally with the external ID from E2E_EXTERNAL_ID.
+func NewAWS(opts ...AWSOption) *AWS {
+	if roleARN := os.Getenv(envAssumeRoleARN); roleARN != "" {
+		return NewAWSWithRole(roleARN, os.Getenv(envExternalID), opts...)
+	}
+	sess := session.Must(session.NewSession())
+	return newAWS(sess, opts...)
+}
+
+// NewAWSWithRole returns a wrapper around the AWS SDK for Go clients whose session
+// credentials come from assuming roleARN. externalID may be empty if the role doesn't
+// require one.
+func NewAWSWithRole(roleARN, externalID string, opts ...AWSOption) *AWS {
+	sess := session.Must(session.NewSession())
+	creds := stscreds.NewCredentials(sess, roleARN, func(p *stscreds.AssumeRoleProvider) {
+		p.RoleSessionName = assumeRoleSessionName
+		if externalID != "" {
+			p.ExternalID = aws.String(externalID)
+		}
+	})
+	assumedSess := sess.Copy(&aws.Config{Credentials: creds})
+	return newAWS(assumedSess, opts...)
+}
+
+func newAWS(sess *session.Session, opts ...AWSOption) *AWS {
+	a := &AWS{
+		sess:  sess,
+		cfn:   cloudformation.New(sess),
+		ecr:   ecr.New(sess),
+		efs:   efs.New(sess),
+		rds:   rds.New(sess),
+		retry: retryPolicy{maxAttempts: defaultRetryMaxAttempts, baseDelay: defaultRetryBaseDelay},
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// execWithRetry runs fn, retrying with exponential backoff and jitter while fn returns a
+// retryable AWS error, up to a.retry.maxAttempts times. fn always runs at least once.
+func (a *AWS) execWithRetry(fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if aerr, ok := err.(awserr.Error); !ok || !retryableErrCodes[aerr.Code()] {
+			return err
+		}
+		if attempt+1 >= a.retry.maxAttempts {
+			return err
+		}
+		time.Sleep(a.retry.backoff(attempt))
+	}
 }
 
-/*CreateStack runs:
-aws cloudformation create-stack
-	--stack-name $name
-	--template-body $templatePath
-*/
-func (a *AWS) CreateStack(name, templatePath string) error {
-	command := strings.Join([]string{
-		"cloudformation",
-		"create-stack",
-		"--stack-name", name,
-		"--template-body", templatePath,
-	}, " ")
-	return a.exec(command)
+// CreateStack creates a CloudFormation stack named name from the template at templatePath.
+// Use WithParameters, WithTags, and WithCapabilities to customize the request.
+func (a *AWS) CreateStack(name, templatePath string, opts ...CreateStackOption) error {
+	body, err := ioutil.ReadFile(templatePath)
+	if err != nil {
+		return fmt.Errorf("read template %s: %w", templatePath, err)
+	}
+	in := &cloudformation.CreateStackInput{
+		StackName:    aws.String(name),
+		TemplateBody: aws.String(string(body)),
+	}
+	for _, opt := range opts {
+		opt(in)
+	}
+	_, err = a.cfn.CreateStack(in)
+	if err != nil {
+		return fmt.Errorf("create stack %s: %w", name, err)
+	}
+	return nil
 }
 
-/*WaitStackCreateComplete runs:
-aws cloudformation wait stack-create-complete
-	--stack-name $name
-*/
+// WaitStackCreateComplete blocks until the stack name finishes creating. If the stack fails
+// to create, the returned error includes the reason reported by the first failed resource.
 func (a *AWS) WaitStackCreateComplete(name string) error {
-	command := strings.Join([]string{
-		"cloudformation",
-		"wait",
-		"stack-create-complete",
-		"--stack-name", name,
-	}, " ")
-	return a.exec(command)
-}
-
-/*VPCStackOutput runs:
-aws cloudformation describe-stacks --stack-name $name |
-	jq -r .Stacks[0].Outputs
-*/
+	err := a.cfn.WaitUntilStackCreateComplete(&cloudformation.DescribeStacksInput{
+		StackName: aws.String(name),
+	})
+	if err != nil {
+		return fmt.Errorf("wait for stack %s create complete: %w", name, a.withFailureReason(name, err))
+	}
+	return nil
+}
+
+// VPCStackOutput returns the outputs of the stack name.
 func (a *AWS) VPCStackOutput(name string) ([]VPCStackOutput, error) {
-	command := strings.Join([]string{
-		"cloudformation",
-		"describe-stacks",
-		"--stack-name", name,
-		"|",
-		"jq", "-r", ".Stacks[0].Outputs",
-	}, " ")
-	var b bytes.Buffer
-	err := a.exec(command, cmd.Stdout(&b))
-	if err != nil {
-		return nil, err
+	var out *cloudformation.DescribeStacksOutput
+	err := a.execWithRetry(func() error {
+		var err error
+		out, err = a.cfn.DescribeStacks(&cloudformation.DescribeStacksInput{
+			StackName: aws.String(name),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describe stack %s: %w", name, err)
+	}
+	if len(out.Stacks) == 0 {
+		return nil, fmt.Errorf("no stack named %s found", name)
 	}
 	var outputs []VPCStackOutput
-	err = json.Unmarshal(b.Bytes(), &outputs)
-	if err != nil {
-		return nil, err
+	for _, o := range out.Stacks[0].Outputs {
+		outputs = append(outputs, VPCStackOutput{
+			OutputKey:   aws.StringValue(o.OutputKey),
+			OutputValue: aws.StringValue(o.OutputValue),
+			ExportName:  aws.StringValue(o.ExportName),
+		})
 	}
 	return outputs, nil
 }
 
-/*DeleteStack runs:
-aws cloudformation delete-stack --stack-name $name
-*/
+// DeleteStack deletes the stack name.
 func (a *AWS) DeleteStack(name string) error {
-	command := strings.Join([]string{
-		"cloudformation",
-		"delete-stack",
-		"--stack-name", name,
-	}, " ")
-	return a.exec(command)
-}
-
-/*WaitStackDeleteComplete runs:
-aws cloudformation wait stack-delete-complete
-	--stack-name $name
-*/
+	_, err := a.cfn.DeleteStack(&cloudformation.DeleteStackInput{
+		StackName: aws.String(name),
+	})
+	if err != nil {
+		return fmt.Errorf("delete stack %s: %w", name, err)
+	}
+	return nil
+}
+
+// WaitStackDeleteComplete blocks until the stack name finishes deleting. If the stack fails
+// to delete, the returned error includes the reason reported by the first failed resource.
 func (a *AWS) WaitStackDeleteComplete(name string) error {
-	command := strings.Join([]string{
-		"cloudformation",
-		"wait",
-		"stack-delete-complete",
-		"--stack-name", name,
-	}, " ")
-	return a.exec(command)
-}
-
-/*CreateECRRepo runs:
-aws ecr create-repository --repository-name $name |
-	jq -r .repository.repositoryUri
-*/
+	err := a.cfn.WaitUntilStackDeleteComplete(&cloudformation.DescribeStacksInput{
+		StackName: aws.String(name),
+	})
+	if err != nil {
+		return fmt.Errorf("wait for stack %s delete complete: %w", name, a.withFailureReason(name, err))
+	}
+	return nil
+}
+
+// withFailureReason augments waitErr with the ResourceStatusReason of the first *_FAILED
+// event in the stack's event log, when one can be found.
+func (a *AWS) withFailureReason(name string, waitErr error) error {
+	events, err := a.StackEvents(name)
+	if err != nil {
+		return waitErr
+	}
+	for _, e := range events {
+		if strings.HasSuffix(e.ResourceStatus, "_FAILED") {
+			return fmt.Errorf("%w: %s %s failed: %s", waitErr, e.ResourceType, e.LogicalResourceID, e.ResourceStatusReason)
+		}
+	}
+	return waitErr
+}
+
+// DescribeStack returns the status, parameters, outputs, and tags of the stack name.
+func (a *AWS) DescribeStack(name string) (*StackDetails, error) {
+	out, err := a.cfn.DescribeStacks(&cloudformation.DescribeStacksInput{
+		StackName: aws.String(name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("describe stack %s: %w", name, err)
+	}
+	if len(out.Stacks) == 0 {
+		return nil, fmt.Errorf("no stack named %s found", name)
+	}
+	stack := out.Stacks[0]
+
+	params := make(map[string]string)
+	for _, p := range stack.Parameters {
+		params[aws.StringValue(p.ParameterKey)] = aws.StringValue(p.ParameterValue)
+	}
+	outputs := make(map[string]string)
+	for _, o := range stack.Outputs {
+		outputs[aws.StringValue(o.OutputKey)] = aws.StringValue(o.OutputValue)
+	}
+	tags := make(map[string]string)
+	for _, t := range stack.Tags {
+		tags[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+	}
+	return &StackDetails{
+		Status:     aws.StringValue(stack.StackStatus),
+		Parameters: params,
+		Outputs:    outputs,
+		Tags:       tags,
+	}, nil
+}
+
+// StackEvents returns the stack name's event log, ordered from oldest to newest.
+func (a *AWS) StackEvents(name string) ([]StackEvent, error) {
+	in := &cloudformation.DescribeStackEventsInput{
+		StackName: aws.String(name),
+	}
+	var stackEvents []*cloudformation.StackEvent
+	for {
+		out, err := a.cfn.DescribeStackEvents(in)
+		if err != nil {
+			return nil, fmt.Errorf("describe stack events for %s: %w", name, err)
+		}
+		stackEvents = append(stackEvents, out.StackEvents...)
+		if out.NextToken == nil {
+			break
+		}
+		in.NextToken = out.NextToken
+	}
+	events := make([]StackEvent, len(stackEvents))
+	for i, e := range stackEvents {
+		// DescribeStackEvents returns events newest-first; reverse into chronological order.
+		events[len(stackEvents)-1-i] = StackEvent{
+			Timestamp:            aws.TimeValue(e.Timestamp),
+			LogicalResourceID:    aws.StringValue(e.LogicalResourceId),
+			ResourceType:         aws.StringValue(e.ResourceType),
+			ResourceStatus:       aws.StringValue(e.ResourceStatus),
+			ResourceStatusReason: aws.StringValue(e.ResourceStatusReason),
+		}
+	}
+	return events, nil
+}
+
+// CreateECRRepo creates an ECR repository named name and returns its URI.
 func (a *AWS) CreateECRRepo(name string) (string, error) {
-	command := strings.Join([]string{
-		"ecr",
-		"create-repository",
-		"--repository-name", name,
-		"|",
-		"jq", "-r", ".repository.repositoryUri",
-	}, " ")
-	var b bytes.Buffer
-	err := a.exec(command, cmd.Stdout(&b))
+	out, err := a.ecr.CreateRepository(&ecr.CreateRepositoryInput{
+		RepositoryName: aws.String(name),
+	})
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("create ECR repository %s: %w", name, err)
 	}
-	return strings.TrimSpace(b.String()), nil
+	return aws.StringValue(out.Repository.RepositoryUri), nil
 }
 
-/*ECRLoginPassword runs:
-aws ecr get-login-password
-*/
+// ECRLoginPassword returns a password that can be used to authenticate against ECR.
 func (a *AWS) ECRLoginPassword() (string, error) {
-	command := strings.Join([]string{
-		"ecr",
-		"get-login-password",
-	}, " ")
-	var b bytes.Buffer
-	err := a.exec(command, cmd.Stdout(&b))
+	out, err := a.ecr.GetAuthorizationToken(&ecr.GetAuthorizationTokenInput{})
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("get ECR authorization token: %w", err)
 	}
-	return strings.TrimSpace(b.String()), nil
+	if len(out.AuthorizationData) == 0 {
+		return "", fmt.Errorf("no authorization data returned")
+	}
+	decoded, err := base64.StdEncoding.DecodeString(aws.StringValue(out.AuthorizationData[0].AuthorizationToken))
+	if err != nil {
+		return "", fmt.Errorf("decode authorization token: %w", err)
+	}
+	parts := strings.SplitN(string(decoded), ":", 2)
+	if len(parts) != 2 {
+		return "", fmt.Errorf("unexpected authorization token format")
+	}
+	return parts[1], nil
 }
 
-/*DeleteECRRepo runs:
-aws ecr delete-repository
-	--repository-name $name --force
-*/
+// DeleteECRRepo force-deletes the ECR repository named name.
 func (a *AWS) DeleteECRRepo(name string) error {
-	command := strings.Join([]string{
-		"ecr",
-		"delete-repository",
-		"--repository-name", name,
-		"--force",
-	}, " ")
-	return a.exec(command)
+	_, err := a.ecr.DeleteRepository(&ecr.DeleteRepositoryInput{
+		RepositoryName: aws.String(name),
+		Force:          aws.Bool(true),
+	})
+	if err != nil {
+		return fmt.Errorf("delete ECR repository %s: %w", name, err)
+	}
+	return nil
 }
 
-func (a *AWS) exec(command string, opts ...cmd.Option) error {
-	return BashExec(fmt.Sprintf("aws %s", command), opts...)
+// FileSystem is an EFS filesystem's identity, size, and lifecycle state.
+type FileSystem struct {
+	ID             string
+	CreationToken  string
+	SizeInBytes    int64
+	LifeCycleState string
 }
 
-/*GetFileSystemSize runs:
-aws efs describe-file-systems | jq -r '.FileSystems[0].SizeInBytes.Value',
-which returns the size in bytes of the first filesystem returned by the call.
-*/
+// DescribeFileSystems returns every EFS filesystem in the account.
+func (a *AWS) DescribeFileSystems() ([]FileSystem, error) {
+	in := &efs.DescribeFileSystemsInput{}
+	var filesystems []FileSystem
+	for {
+		var out *efs.DescribeFileSystemsOutput
+		err := a.execWithRetry(func() error {
+			var err error
+			out, err = a.efs.DescribeFileSystems(in)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("describe file systems: %w", err)
+		}
+		for _, fs := range out.FileSystems {
+			filesystems = append(filesystems, FileSystem{
+				ID:             aws.StringValue(fs.FileSystemId),
+				CreationToken:  aws.StringValue(fs.CreationToken),
+				SizeInBytes:    aws.Int64Value(fs.SizeInBytes.Value),
+				LifeCycleState: aws.StringValue(fs.LifeCycleState),
+			})
+		}
+		if out.NextMarker == nil {
+			return filesystems, nil
+		}
+		in.Marker = out.NextMarker
+	}
+}
+
+// GetFileSystemSize returns the size in bytes of the first EFS filesystem returned by the
+// account. Deprecated: prefer GetFileSystemSizeByID now that an account may have more than
+// one copilot environment's filesystem.
 func (a *AWS) GetFileSystemSize() (int, error) {
-	command := strings.Join([]string{
-		"efs",
-		"describe-file-systems",
-		"|",
-		"jq", "-r", "'.FileSystems[0].SizeInBytes.Value'",
-	}, " ")
-	var b bytes.Buffer
-	err := a.exec(command, cmd.Stdout(&b))
+	filesystems, err := a.DescribeFileSystems()
 	if err != nil {
 		return 0, err
 	}
-	return strconv.Atoi(strings.TrimSpace(b.String()))
+	if len(filesystems) == 0 {
+		return 0, fmt.Errorf("no file systems found")
+	}
+	return int(filesystems[0].SizeInBytes), nil
 }
 
-// DeleteAllDBClusterSnapshots removes all "manual" RDS cluster snapshots to avoid running into snapshot limits.
-func (a *AWS) DeleteAllDBClusterSnapshots() error {
-	command := strings.Join([]string{
-		"rds",
-		"describe-db-cluster-snapshots",
-	}, " ")
-	var b bytes.Buffer
-	err := a.exec(command, cmd.Stdout(&b))
+// GetFileSystemSizeByID returns the size in bytes of the EFS filesystem fsID.
+func (a *AWS) GetFileSystemSizeByID(fsID string) (int64, error) {
+	var out *efs.DescribeFileSystemsOutput
+	err := a.execWithRetry(func() error {
+		var err error
+		out, err = a.efs.DescribeFileSystems(&efs.DescribeFileSystemsInput{
+			FileSystemId: aws.String(fsID),
+		})
+		return err
+	})
 	if err != nil {
+		return 0, fmt.Errorf("describe file system %s: %w", fsID, err)
+	}
+	if len(out.FileSystems) == 0 {
+		return 0, fmt.Errorf("no file system named %s found", fsID)
+	}
+	return aws.Int64Value(out.FileSystems[0].SizeInBytes.Value), nil
+}
+
+// ListEFSAccessPoints returns every access point on the EFS filesystem fsID.
+func (a *AWS) ListEFSAccessPoints(fsID string) ([]*efs.AccessPointDescription, error) {
+	in := &efs.DescribeAccessPointsInput{
+		FileSystemId: aws.String(fsID),
+	}
+	var accessPoints []*efs.AccessPointDescription
+	for {
+		var out *efs.DescribeAccessPointsOutput
+		err := a.execWithRetry(func() error {
+			var err error
+			out, err = a.efs.DescribeAccessPoints(in)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list access points for file system %s: %w", fsID, err)
+		}
+		accessPoints = append(accessPoints, out.AccessPointDescriptions...)
+		if out.NextToken == nil {
+			return accessPoints, nil
+		}
+		in.NextToken = out.NextToken
+	}
+}
+
+// DeleteEFSAccessPoint deletes the EFS access point apID.
+func (a *AWS) DeleteEFSAccessPoint(apID string) error {
+	err := a.execWithRetry(func() error {
+		_, err := a.efs.DeleteAccessPoint(&efs.DeleteAccessPointInput{
+			AccessPointId: aws.String(apID),
+		})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("delete access point %s: %w", apID, err)
+	}
+	return nil
+}
+
+// DeleteFileSystem deletes the EFS filesystem fsID, first deleting its access points and
+// mount targets, since EFS refuses to delete a filesystem that still has either.
+func (a *AWS) DeleteFileSystem(fsID string) error {
+	accessPoints, err := a.ListEFSAccessPoints(fsID)
+	if err != nil {
+		return err
+	}
+	for _, ap := range accessPoints {
+		if err := a.DeleteEFSAccessPoint(aws.StringValue(ap.AccessPointId)); err != nil {
+			return err
+		}
+	}
+
+	var mountTargets *efs.DescribeMountTargetsOutput
+	err = a.execWithRetry(func() error {
+		var err error
+		mountTargets, err = a.efs.DescribeMountTargets(&efs.DescribeMountTargetsInput{
+			FileSystemId: aws.String(fsID),
+		})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("list mount targets for file system %s: %w", fsID, err)
+	}
+	for _, mt := range mountTargets.MountTargets {
+		err := a.execWithRetry(func() error {
+			_, err := a.efs.DeleteMountTarget(&efs.DeleteMountTargetInput{
+				MountTargetId: mt.MountTargetId,
+			})
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("delete mount target %s: %w", aws.StringValue(mt.MountTargetId), err)
+		}
+	}
+	if err := a.waitMountTargetsDeleted(fsID); err != nil {
 		return err
 	}
-	var snapshotResponse struct {
-		Snapshots []dbClusterSnapshot `json:"DBClusterSnapshots"`
+
+	err = a.execWithRetry(func() error {
+		_, err := a.efs.DeleteFileSystem(&efs.DeleteFileSystemInput{
+			FileSystemId: aws.String(fsID),
+		})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("delete file system %s: %w", fsID, err)
 	}
-	if err = json.Unmarshal(b.Bytes(), &snapshotResponse); err != nil {
+	return nil
+}
+
+// waitMountTargetsDeleted blocks until fsID has no mount targets left, since EFS deletes
+// them asynchronously.
+func (a *AWS) waitMountTargetsDeleted(fsID string) error {
+	for attempt := 0; attempt < a.retry.maxAttempts; attempt++ {
+		var out *efs.DescribeMountTargetsOutput
+		err := a.execWithRetry(func() error {
+			var err error
+			out, err = a.efs.DescribeMountTargets(&efs.DescribeMountTargetsInput{
+				FileSystemId: aws.String(fsID),
+			})
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("list mount targets for file system %s: %w", fsID, err)
+		}
+		if len(out.MountTargets) == 0 {
+			return nil
+		}
+		time.Sleep(a.retry.backoff(attempt))
+	}
+	return fmt.Errorf("timed out waiting for mount targets on file system %s to delete", fsID)
+}
+
+// DeleteAllDBClusterSnapshots removes all "manual" RDS cluster snapshots to avoid running into snapshot limits.
+func (a *AWS) DeleteAllDBClusterSnapshots() error {
+	snapshots, err := a.ListDBClusterSnapshots(func(s *rds.DBClusterSnapshot) bool {
+		return aws.StringValue(s.SnapshotType) == "manual"
+	})
+	if err != nil {
 		return err
 	}
-	for _, s := range snapshotResponse.Snapshots {
-		deleteCmd := strings.Join([]string{
-			"rds",
-			"delete-db-cluster-snapshot",
-			"--db-cluster-snapshot-identifier",
-			s.Identifier,
-		}, " ")
-		var err = a.exec(deleteCmd)
+	for _, s := range snapshots {
+		_, err := a.rds.DeleteDBClusterSnapshot(&rds.DeleteDBClusterSnapshotInput{
+			DBClusterSnapshotIdentifier: s.DBClusterSnapshotIdentifier,
+		})
 		if err != nil {
+			return fmt.Errorf("delete DB cluster snapshot %s: %w", aws.StringValue(s.DBClusterSnapshotIdentifier), err)
+		}
+	}
+	return nil
+}
+
+// ListDBClusterSnapshots returns every RDS cluster snapshot in the account for which filter
+// returns true. A nil filter returns every snapshot.
+func (a *AWS) ListDBClusterSnapshots(filter func(*rds.DBClusterSnapshot) bool) ([]*rds.DBClusterSnapshot, error) {
+	in := &rds.DescribeDBClusterSnapshotsInput{}
+	var snapshots []*rds.DBClusterSnapshot
+	for {
+		var out *rds.DescribeDBClusterSnapshotsOutput
+		err := a.execWithRetry(func() error {
+			var err error
+			out, err = a.rds.DescribeDBClusterSnapshots(in)
 			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("describe DB cluster snapshots: %w", err)
+		}
+		for _, s := range out.DBClusterSnapshots {
+			if filter == nil || filter(s) {
+				snapshots = append(snapshots, s)
+			}
+		}
+		if out.Marker == nil {
+			return snapshots, nil
+		}
+		in.Marker = out.Marker
+	}
+}
+
+// CreateDBClusterSnapshot creates a manual snapshot named snapshotID of the cluster clusterID.
+func (a *AWS) CreateDBClusterSnapshot(clusterID, snapshotID string) error {
+	_, err := a.rds.CreateDBClusterSnapshot(&rds.CreateDBClusterSnapshotInput{
+		DBClusterIdentifier:         aws.String(clusterID),
+		DBClusterSnapshotIdentifier: aws.String(snapshotID),
+	})
+	if err != nil {
+		return fmt.Errorf("create DB cluster snapshot %s of cluster %s: %w", snapshotID, clusterID, err)
+	}
+	return nil
+}
+
+// WaitDBClusterSnapshotAvailable blocks until snapshotID's status becomes "available", polling
+// on the same schedule as execWithRetry.
+func (a *AWS) WaitDBClusterSnapshotAvailable(snapshotID string) error {
+	var lastStatus string
+	for attempt := 0; attempt < a.retry.maxAttempts; attempt++ {
+		out, err := a.rds.DescribeDBClusterSnapshots(&rds.DescribeDBClusterSnapshotsInput{
+			DBClusterSnapshotIdentifier: aws.String(snapshotID),
+		})
+		if err != nil {
+			return fmt.Errorf("describe DB cluster snapshot %s: %w", snapshotID, err)
+		}
+		if len(out.DBClusterSnapshots) == 0 {
+			return fmt.Errorf("no snapshot named %s found", snapshotID)
+		}
+		lastStatus = aws.StringValue(out.DBClusterSnapshots[0].Status)
+		if lastStatus == "available" {
+			return nil
 		}
+		time.Sleep(a.retry.backoff(attempt))
+	}
+	return fmt.Errorf("timed out waiting for DB cluster snapshot %s to become available, last status: %s", snapshotID, lastStatus)
+}
+
+// ShareDBClusterSnapshot grants accountIDs permission to restore from snapshotID.
+func (a *AWS) ShareDBClusterSnapshot(snapshotID string, accountIDs []string) error {
+	_, err := a.rds.ModifyDBClusterSnapshotAttribute(&rds.ModifyDBClusterSnapshotAttributeInput{
+		DBClusterSnapshotIdentifier: aws.String(snapshotID),
+		AttributeName:               aws.String("restore"),
+		ValuesToAdd:                 aws.StringSlice(accountIDs),
+	})
+	if err != nil {
+		return fmt.Errorf("share DB cluster snapshot %s with %v: %w", snapshotID, accountIDs, err)
+	}
+	return nil
+}
+
+// UnshareDBClusterSnapshot revokes accountIDs' permission to restore from snapshotID.
+func (a *AWS) UnshareDBClusterSnapshot(snapshotID string, accountIDs []string) error {
+	_, err := a.rds.ModifyDBClusterSnapshotAttribute(&rds.ModifyDBClusterSnapshotAttributeInput{
+		DBClusterSnapshotIdentifier: aws.String(snapshotID),
+		AttributeName:               aws.String("restore"),
+		ValuesToRemove:              aws.StringSlice(accountIDs),
+	})
+	if err != nil {
+		return fmt.Errorf("unshare DB cluster snapshot %s from %v: %w", snapshotID, accountIDs, err)
 	}
 	return nil
 }